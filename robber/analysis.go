@@ -1,6 +1,7 @@
 package robber
 
 import (
+	"context"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -33,12 +34,19 @@ func AnalyzeEntropyDiff(m *Middleware, diffObject *DiffObject) {
 }
 
 // AnalyzeRegexDiff runs line by line on a given diff and runs each given regex rule on the line.
-func AnalyzeRegexDiff(m *Middleware, diffObject *DiffObject) {
+func AnalyzeRegexDiff(ctx context.Context, m *Middleware, diffObject *DiffObject) {
 	lines := strings.Split(*diffObject.Diff, "\n")
 	numOfLines := len(lines)
 
+	var applicableRules []*Rule
+	for _, rule := range m.Rules {
+		if rule.AppliesToPath(*diffObject.Filepath) {
+			applicableRules = append(applicableRules, rule)
+		}
+	}
+
 	for lineNum, line := range lines {
-		for _, rule := range m.Rules {
+		for _, rule := range applicableRules {
 			if found := rule.Regex.FindString(line); found != "" {
 				start, end := Max(0, lineNum-*m.Flags.Context), Min(numOfLines, lineNum+*m.Flags.Context+1)
 				context := lines[start:end]
@@ -50,9 +58,13 @@ func AnalyzeRegexDiff(m *Middleware, diffObject *DiffObject) {
 				if *m.Flags.SkipDuplicates && !m.SecretExists(*diffObject.Reponame, secretString) {
 					m.AddSecret(*diffObject.Reponame, secretString)
 					finding := NewFinding(rule.Reason, secret, diffObject)
+					verifySecret(ctx, m, rule, secretString, finding)
+					blameFinding(m, diffObject, secretString, finding)
 					m.Logger.LogFinding(finding, m, newDiff)
 				} else if !*m.Flags.SkipDuplicates {
 					finding := NewFinding(rule.Reason, secret, diffObject)
+					verifySecret(ctx, m, rule, secretString, finding)
+					blameFinding(m, diffObject, secretString, finding)
 					m.Logger.LogFinding(finding, m, newDiff)
 				}
 			}
@@ -60,95 +72,179 @@ func AnalyzeRegexDiff(m *Middleware, diffObject *DiffObject) {
 	}
 }
 
-// AnalyzeRepo opens a given repository and extracts all diffs from it for later analysis.
-func AnalyzeRepo(m *Middleware, id int, repoch <-chan string, quit chan<- bool, done <-chan bool, wg *sync.WaitGroup) {
+// verifySecret runs finding's secret through m.VerifyPool when --verify is
+// set, tagging finding with the result. Canceling ctx (e.g. --per-repo-timeout
+// firing mid-scan) aborts the verification instead of blocking on it.
+func verifySecret(ctx context.Context, m *Middleware, rule *Rule, secret string, finding *Finding) {
+	if m.Flags.Verify == nil || !*m.Flags.Verify {
+		return
+	}
+	finding.Verification = m.VerifyPool.Verify(ctx, m, rule, secret)
+}
+
+// blameFinding attributes secret to whoever last touched it in HEAD, when
+// diffObject carries a repo to blame against.
+func blameFinding(m *Middleware, diffObject *DiffObject, secret string, finding *Finding) {
+	if diffObject.Repo == nil {
+		return
+	}
+	BlameFinding(m, diffObject.Repo, *diffObject.Reponame, *diffObject.Filepath, secret, finding)
+}
+
+// AnalyzeRepo pulls repo clone URLs off repoch and scans each in turn until
+// ctx is canceled, either by the caller or by cancel once the last queued
+// repo has finished (see queueRepos).
+func AnalyzeRepo(ctx context.Context, m *Middleware, id int, repoch <-chan string, cancel context.CancelFunc, wg *sync.WaitGroup) {
+	defer wg.Done()
 	for {
 		select {
 		case reponame := <-repoch:
-			repo, err := OpenRepo(m, reponame)
-			if err != nil {
-				if err == transport.ErrEmptyRemoteRepository {
-					m.Logger.LogWarn("%s is empty\n", reponame)
-					atomic.AddInt32(m.RepoCount, -1)
-					if atomic.LoadInt32(m.RepoCount) == 0 {
-						quit <- true
-					}
-					continue
-				}
-				m.Logger.LogFail("Unable to open repo %s: %s\n", reponame, err)
+			scanRepo(ctx, m, reponame)
+			atomic.AddInt32(m.RepoCount, -1)
+			if atomic.LoadInt32(m.RepoCount) == 0 {
+				cancel()
 			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-			commits, err := GetCommits(m, repo, reponame)
-			if err != nil {
-				m.Logger.LogWarn("Unable to fetch commits for %s: %s\n", reponame, err)
+// scanRepo opens reponame and extracts all diffs from it for later analysis,
+// bounded by ctx and, if set, --per-repo-timeout.
+func scanRepo(ctx context.Context, m *Middleware, reponame string) {
+	if *m.Flags.PerRepoTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *m.Flags.PerRepoTimeout)
+		defer cancel()
+	}
+
+	repo, err := OpenRepo(ctx, m, reponame)
+	if err != nil {
+		if err == transport.ErrEmptyRemoteRepository {
+			m.Logger.LogWarn("%s is empty\n", reponame)
+			return
+		}
+		m.Logger.LogFail("Unable to open repo %s: %s\n", reponame, err)
+		return
+	}
+
+	commits, err := GetCommits(ctx, m, repo, reponame)
+	if err != nil {
+		m.Logger.LogWarn("Unable to fetch commits for %s: %s\n", reponame, err)
+		return
+	}
+	headRef, err := repo.Head()
+	if err != nil {
+		m.Logger.LogWarn("Unable to resolve HEAD for %s: %s\n", reponame, err)
+		return
+	}
+	head, err := repo.CommitObject(headRef.Hash())
+	if err != nil {
+		m.Logger.LogWarn("Unable to resolve HEAD for %s: %s\n", reponame, err)
+		return
+	}
+	pathFilter := NewPathFilter(head, *m.Flags.ExcludePaths)
+
+	// Get all changes in correct order of commit history
+	for index := range commits {
+		if ctx.Err() != nil {
+			return
+		}
+		commit := commits[len(commits)-index-1]
+		changes, err := GetCommitChanges(commit)
+		if err != nil {
+			m.Logger.LogWarn("Unable to get commit changes for hash %s: %s\n", commit.Hash, err)
+			continue
+		}
+
+		for _, change := range changes {
+			if ctx.Err() != nil {
 				return
 			}
+			changePath, err := ChangePath(change)
+			if err != nil {
+				m.Logger.LogWarn("Unable to resolve path of %s: %s\n", change, err)
+				continue
+			}
+			if pathFilter.Excluded(changePath) {
+				continue
+			}
 
-			// Get all changes in correct order of commit history
-			for index := range commits {
-				commit := commits[len(commits)-index-1]
-				changes, err := GetCommitChanges(commit)
-				if err != nil {
-					m.Logger.LogWarn("Unable to get commit changes for hash %s: %s\n", commit.Hash, err)
-					continue
-				}
-
-				for _, change := range changes {
-					diffs, filepath, err := GetDiffs(m, change, reponame)
-					if err != nil {
-						m.Logger.LogWarn("Unable to get diffs of %s: %s\n", change, err)
-						continue
-					}
-					for _, diff := range diffs {
-						diffObject := NewDiffObject(commit, &diff, &reponame, &filepath)
-						if *m.Flags.Both {
-							AnalyzeRegexDiff(m, diffObject)
-							AnalyzeEntropyDiff(m, diffObject)
-						} else if *m.Flags.Entropy {
-							AnalyzeEntropyDiff(m, diffObject)
-						} else {
-							AnalyzeRegexDiff(m, diffObject)
-						}
-					}
-				}
+			diffs, filepath, err := GetDiffs(m, change, reponame)
+			if err != nil {
+				m.Logger.LogWarn("Unable to get diffs of %s: %s\n", change, err)
+				continue
 			}
-			atomic.AddInt32(m.RepoCount, -1)
-			if atomic.LoadInt32(m.RepoCount) == 0 {
-				quit <- true
+			for _, diff := range diffs {
+				diffObject := NewDiffObject(repo, commit, &diff, &reponame, &filepath)
+				if *m.Flags.Both {
+					AnalyzeRegexDiff(ctx, m, diffObject)
+					AnalyzeEntropyDiff(m, diffObject)
+				} else if *m.Flags.Entropy {
+					AnalyzeEntropyDiff(m, diffObject)
+				} else {
+					AnalyzeRegexDiff(ctx, m, diffObject)
+				}
 			}
-		case <-done:
-			wg.Done()
-			return
 		}
 	}
 }
 
-// AnalyzeUser simply sends a GET request on githubs API for a given username
-// and starts and analysis of each of the user's repositories.
-func AnalyzeUser(m *Middleware, username string, repoch chan<- string) {
-	repos := GetUserRepos(m, username)
-	atomic.AddInt32(m.RepoCount, int32(len(repos)))
+// queueRepos consults m.RepoCache for each repo and, for the ones that pass,
+// increments m.RepoCount and pushes their clone URL onto repoch. Checking the
+// cache before touching RepoCount keeps the zero-RepoCount cancellation in
+// AnalyzeRepo correct in the face of duplicate or filtered-out repositories.
+// Selecting on ctx.Done() around the send keeps this from blocking forever
+// once ctx is canceled and AnalyzeRepo's workers have stopped draining repoch.
+func queueRepos(ctx context.Context, m *Middleware, repos []*Repo, repoch chan<- string) {
 	for _, repo := range repos {
-		repoch <- *repo
+		if !m.RepoCache.ShouldScan(repo) {
+			continue
+		}
+		atomic.AddInt32(m.RepoCount, 1)
+		select {
+		case repoch <- repo.CloneURL:
+		case <-ctx.Done():
+			return
+		}
 	}
 }
 
-// AnalyzeOrg simply sends two GET requests to githubs API, one for a given organizations
-// repositories and one for its' members.
-func AnalyzeOrg(m *Middleware, orgname string, repoch chan<- string) {
-	var members []*string
+// AnalyzeUser queries m.Forge for a given username's repositories and
+// starts an analysis of each of them.
+func AnalyzeUser(ctx context.Context, m *Middleware, username string, repoch chan<- string) {
+	repos, err := m.Forge.ListUserRepos(ctx, username)
+	if err != nil {
+		m.Logger.LogWarn("Unable to list repos for user %s: %s\n", username, err)
+		return
+	}
+	queueRepos(ctx, m, repos, repoch)
+}
+
+// AnalyzeOrg queries m.Forge for a given organization's repositories and,
+// if requested, its members, starting an analysis of each.
+func AnalyzeOrg(ctx context.Context, m *Middleware, orgname string, repoch chan<- string) {
+	var members []string
 	if *m.Flags.IncludeMembers {
-		members = GetOrgMembers(m, orgname)
-	} else {
-		members = []*string{}
+		var err error
+		members, err = m.Forge.ListOrgMembers(ctx, orgname)
+		if err != nil {
+			m.Logger.LogWarn("Unable to list members for org %s: %s\n", orgname, err)
+		}
 	}
-	repos := GetOrgRepos(m, orgname)
-	atomic.AddInt32(m.RepoCount, int32(len(repos)))
 
-	for _, repo := range repos {
-		repoch <- *repo
+	repos, err := m.Forge.ListOrgRepos(ctx, orgname)
+	if err != nil {
+		m.Logger.LogWarn("Unable to list repos for org %s: %s\n", orgname, err)
+		return
 	}
+	queueRepos(ctx, m, repos, repoch)
+
 	for _, member := range members {
-		AnalyzeUser(m, *member, repoch)
+		if ctx.Err() != nil {
+			return
+		}
+		AnalyzeUser(ctx, m, member, repoch)
 	}
 }
@@ -0,0 +1,103 @@
+package robber
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type ctxCapturingVerifier struct {
+	sawCtx context.Context
+}
+
+func (v *ctxCapturingVerifier) CanVerify(rule *Rule) bool { return true }
+
+func (v *ctxCapturingVerifier) Verify(ctx context.Context, secret string) (VerificationResult, error) {
+	v.sawCtx = ctx
+	return VerificationVerified, nil
+}
+
+func TestVerifySecretForwardsCallerContext(t *testing.T) {
+	verifier := &ctxCapturingVerifier{}
+	verify := true
+	m := &Middleware{
+		Flags:      &Flags{Verify: &verify},
+		Verifiers:  []Verifier{verifier},
+		VerifyPool: NewVerifyPool(1, 0),
+	}
+	rule := &Rule{Reason: "test"}
+	finding := &Finding{}
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "caller-ctx")
+
+	verifySecret(ctx, m, rule, "secret", finding)
+
+	if verifier.sawCtx == nil || verifier.sawCtx.Value(key{}) != "caller-ctx" {
+		t.Fatal("verifySecret should forward the caller's ctx to VerifyPool/Verifier instead of a detached context.Background()")
+	}
+	if finding.Verification != VerificationVerified {
+		t.Fatalf("finding.Verification = %v, want %v", finding.Verification, VerificationVerified)
+	}
+}
+
+func TestVerifySecretHonorsCanceledContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	blocker := &stubVerifier{canVerify: true, release: release}
+	verify := true
+	m := &Middleware{
+		Flags:      &Flags{Verify: &verify},
+		Verifiers:  []Verifier{blocker},
+		VerifyPool: NewVerifyPool(1, 0),
+	}
+	rule := &Rule{Reason: "test"}
+
+	// Occupy the pool's single slot so the next Verify call must wait on ctx.
+	done := make(chan struct{})
+	go func() {
+		verifySecret(context.Background(), m, rule, "holds the slot", &Finding{})
+		close(done)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	finding := &Finding{}
+	verifySecret(ctx, m, rule, "secret", finding)
+	if finding.Verification != VerificationUnknown {
+		t.Fatalf("verifySecret with a canceled ctx set Verification = %v, want %v", finding.Verification, VerificationUnknown)
+	}
+
+	release <- struct{}{}
+	<-done
+}
+
+func TestQueueReposReturnsOnCanceledContextInsteadOfBlocking(t *testing.T) {
+	var repoCount int32
+	m := &Middleware{RepoCache: NewRepoCache(nil, nil, true, true), RepoCount: &repoCount}
+	repos := []*Repo{
+		{Name: "a/a", CloneURL: "https://example.com/a/a"},
+		{Name: "b/b", CloneURL: "https://example.com/b/b"},
+	}
+	// Unbuffered and never drained: simulates AnalyzeRepo's workers having
+	// already exited after ctx was canceled.
+	repoch := make(chan string)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		queueRepos(ctx, m, repos, repoch)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("queueRepos blocked on repoch<- after ctx was canceled, instead of returning")
+	}
+}
@@ -0,0 +1,110 @@
+package robber
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const defaultBitbucketBaseURL = "https://api.bitbucket.org/2.0"
+
+// BitbucketForge implements Forge against bitbucket.org or Bitbucket Server.
+type BitbucketForge struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewBitbucketForge builds a BitbucketForge. An empty baseURL targets bitbucket.org.
+func NewBitbucketForge(baseURL, token string) *BitbucketForge {
+	if baseURL == "" {
+		baseURL = defaultBitbucketBaseURL
+	}
+	return &BitbucketForge{baseURL: baseURL, token: token, client: http.DefaultClient}
+}
+
+func (b *BitbucketForge) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", b.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bitbucket: %s: unexpected status %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type bitbucketRepoPage struct {
+	Values []struct {
+		FullName string `json:"full_name"`
+		Parent   *struct {
+			FullName string `json:"full_name"`
+		} `json:"parent"`
+		Links struct {
+			Clone []struct {
+				Name string `json:"name"`
+				Href string `json:"href"`
+			} `json:"clone"`
+		} `json:"links"`
+	} `json:"values"`
+}
+
+func (p bitbucketRepoPage) repos() []*Repo {
+	var repos []*Repo
+	for _, repo := range p.Values {
+		r := &Repo{Name: repo.FullName, IsFork: repo.Parent != nil}
+		for _, clone := range repo.Links.Clone {
+			if clone.Name == "https" {
+				r.CloneURL = clone.Href
+			}
+		}
+		repos = append(repos, r)
+	}
+	return repos
+}
+
+// ListUserRepos implements Forge, treating user as a Bitbucket workspace.
+func (b *BitbucketForge) ListUserRepos(ctx context.Context, user string) ([]*Repo, error) {
+	var page bitbucketRepoPage
+	if err := b.get(ctx, "/repositories/"+user, &page); err != nil {
+		return nil, err
+	}
+	return page.repos(), nil
+}
+
+// ListOrgRepos implements Forge, treating org as a Bitbucket workspace.
+func (b *BitbucketForge) ListOrgRepos(ctx context.Context, org string) ([]*Repo, error) {
+	var page bitbucketRepoPage
+	if err := b.get(ctx, "/repositories/"+org, &page); err != nil {
+		return nil, err
+	}
+	return page.repos(), nil
+}
+
+// ListOrgMembers implements Forge.
+func (b *BitbucketForge) ListOrgMembers(ctx context.Context, org string) ([]string, error) {
+	var page struct {
+		Values []struct {
+			User struct {
+				Nickname string `json:"nickname"`
+			} `json:"user"`
+		} `json:"values"`
+	}
+	if err := b.get(ctx, "/workspaces/"+org+"/members", &page); err != nil {
+		return nil, err
+	}
+	logins := make([]string, len(page.Values))
+	for i, v := range page.Values {
+		logins[i] = v.User.Nickname
+	}
+	return logins, nil
+}
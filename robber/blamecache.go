@@ -0,0 +1,44 @@
+package robber
+
+import (
+	"sync"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// BlameCache memoizes git.Blame results per (repo, file), since every
+// finding in a file reuses the same blame data. It is safe for concurrent
+// use across the goroutines spawned per repo.
+type BlameCache struct {
+	mu     sync.Mutex
+	blames map[string]*git.BlameResult
+}
+
+// NewBlameCache builds an empty BlameCache.
+func NewBlameCache() *BlameCache {
+	return &BlameCache{blames: make(map[string]*git.BlameResult)}
+}
+
+// Blame returns the blame of filepath at head, computing and caching it on
+// first use for the given reponame/filepath pair.
+func (c *BlameCache) Blame(reponame, filepath string, head *object.Commit) (*git.BlameResult, error) {
+	key := reponame + "\x00" + filepath
+
+	c.mu.Lock()
+	if blame, ok := c.blames[key]; ok {
+		c.mu.Unlock()
+		return blame, nil
+	}
+	c.mu.Unlock()
+
+	blame, err := git.Blame(head, filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.blames[key] = blame
+	c.mu.Unlock()
+	return blame, nil
+}
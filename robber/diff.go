@@ -0,0 +1,22 @@
+package robber
+
+import (
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// DiffObject carries a single file diff alongside the commit and repo
+// metadata needed to turn a match into a Finding.
+type DiffObject struct {
+	Repo     *git.Repository
+	Commit   *object.Commit
+	Diff     *string
+	Reponame *string
+	Filepath *string
+}
+
+// NewDiffObject builds a DiffObject for a single file diff found in commit.
+// repo lets a match be blamed against the file's current HEAD version.
+func NewDiffObject(repo *git.Repository, commit *object.Commit, diff *string, reponame *string, filepath *string) *DiffObject {
+	return &DiffObject{Repo: repo, Commit: commit, Diff: diff, Reponame: reponame, Filepath: filepath}
+}
@@ -0,0 +1,77 @@
+package robber
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Emitter renders Findings in a specific output format. Logger delegates to
+// the configured Emitter so --output-format can plug in json/sarif/pretty
+// without the scan loop knowing which is active.
+type Emitter interface {
+	// EmitFinding renders a single Finding, given the text its Secret
+	// [start,end) offsets are relative to: the context window for a regex
+	// match, or the candidate string itself for an entropy match.
+	EmitFinding(finding *Finding, m *Middleware, context string)
+	// Flush writes any output buffered for document-shaped formats (json,
+	// sarif) and is called once the scan completes. A no-op for emitters
+	// that write as they go.
+	Flush() error
+}
+
+// NewEmitter builds the Emitter selected by name, writing to w. An empty
+// name or "pretty" is yar's original human-readable printer.
+func NewEmitter(name string, w io.Writer) (Emitter, error) {
+	switch name {
+	case "", "pretty":
+		return NewPrettyEmitter(w), nil
+	case "json":
+		return NewJSONEmitter(w), nil
+	case "sarif":
+		return NewSARIFEmitter(w), nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q", name)
+	}
+}
+
+// secretText returns the matched secret itself, sliced out of context by
+// finding.Secret's [start,end) offsets.
+func secretText(finding *Finding, context string) string {
+	if len(finding.Secret) != 2 {
+		return ""
+	}
+	start, end := finding.Secret[0], finding.Secret[1]
+	if start < 0 || end > len(context) || start > end {
+		return ""
+	}
+	return context[start:end]
+}
+
+// fingerprint hashes repo, file and secret into a stable identifier that
+// SARIF/JSON consumers can use to dedup the same finding across scans.
+func fingerprint(reponame, filepath, secret string) string {
+	sum := sha256.Sum256([]byte(reponame + "\x00" + filepath + "\x00" + secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// slugify turns reason into a lowercase, hyphen-separated identifier
+// suitable for use as a SARIF ruleId.
+func slugify(reason string) string {
+	var b strings.Builder
+	lastDash := false
+	for _, r := range strings.ToLower(reason) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			lastDash = false
+		} else if !lastDash {
+			b.WriteByte('-')
+			lastDash = true
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
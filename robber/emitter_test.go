@@ -0,0 +1,51 @@
+package robber
+
+import "testing"
+
+func TestSecretText(t *testing.T) {
+	finding := &Finding{Secret: []int{4, 10}}
+	if got := secretText(finding, "abcdSECRETxyz"); got != "SECRET" {
+		t.Fatalf("secretText() = %q, want %q", got, "SECRET")
+	}
+}
+
+func TestSecretTextOutOfRangeIsEmpty(t *testing.T) {
+	cases := []*Finding{
+		{Secret: nil},
+		{Secret: []int{-1, 3}},
+		{Secret: []int{5, 100}},
+		{Secret: []int{5, 2}},
+	}
+	for _, f := range cases {
+		if got := secretText(f, "abcdefg"); got != "" {
+			t.Errorf("secretText(%v) = %q, want empty", f.Secret, got)
+		}
+	}
+}
+
+func TestFingerprintIsStableAndDistinguishesInputs(t *testing.T) {
+	a := fingerprint("repo", "file.go", "secret")
+	b := fingerprint("repo", "file.go", "secret")
+	if a != b {
+		t.Fatal("fingerprint should be deterministic for the same inputs")
+	}
+	if a == fingerprint("repo", "file.go", "other-secret") {
+		t.Fatal("fingerprint should differ when the secret differs")
+	}
+	if a == fingerprint("other-repo", "file.go", "secret") {
+		t.Fatal("fingerprint should differ when the repo differs")
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"AWS Access Key":    "aws-access-key",
+		"  Slack--Token!! ": "slack-token",
+		"PEM_Block":         "pem-block",
+	}
+	for in, want := range cases {
+		if got := slugify(in); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
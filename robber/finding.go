@@ -0,0 +1,45 @@
+package robber
+
+// Finding represents a single potential secret detected within a diff.
+type Finding struct {
+	Reason       string
+	Secret       []int
+	Commit       string
+	Reponame     string
+	Filepath     string
+	Verification VerificationResult
+
+	// LastAuthor and LastAuthorEmail identify whoever last touched the
+	// matching line in HEAD, per git blame. Empty when the finding could
+	// not be blamed, e.g. the file no longer exists at HEAD.
+	LastAuthor      string
+	LastAuthorEmail string
+	// LastCommit is the hash of the commit git blame attributes that line to.
+	LastCommit string
+	// LineInHEAD is the 1-based line number of the secret in the file's
+	// current HEAD revision, or 0 if it could not be located there.
+	LineInHEAD int
+	// LineInHEADText is the full text of the line LineInHEAD refers to,
+	// letting consumers (e.g. the SARIF emitter) locate the secret's
+	// column within it without re-reading the file from git themselves.
+	LineInHEADText string
+	// StillPresent reports whether the secret text still appears in the
+	// file at HEAD, as opposed to having since been removed or rotated.
+	StillPresent bool
+}
+
+// NewFinding builds a Finding from a matched rule reason, the secret's
+// [start,end) byte offsets within the reported diff, and the diff object it
+// was found in. Verification defaults to VerificationUnknown until a
+// Verifier is run against it; the HEAD attribution fields are left zero
+// until BlameFinding runs.
+func NewFinding(reason string, secret []int, d *DiffObject) *Finding {
+	return &Finding{
+		Reason:       reason,
+		Secret:       secret,
+		Commit:       d.Commit.Hash.String(),
+		Reponame:     *d.Reponame,
+		Filepath:     *d.Filepath,
+		Verification: VerificationUnknown,
+	}
+}
@@ -0,0 +1,56 @@
+package robber
+
+import "time"
+
+// Flags holds the parsed command line configuration for a scan.
+type Flags struct {
+	Context        *int
+	Both           *bool
+	Entropy        *bool
+	SkipDuplicates *bool
+	IncludeMembers *bool
+
+	// Timeout bounds the whole scan; 0 lets it run until every queued repo
+	// is processed or it is canceled (e.g. by SIGINT).
+	Timeout *time.Duration
+	// PerRepoTimeout bounds how long a single repo's clone and analysis may
+	// take before it is abandoned; 0 disables the per-repo deadline.
+	PerRepoTimeout *time.Duration
+
+	// Forge selects which remote code hosting platform to enumerate
+	// repositories from: "github" (default), "gitlab", "bitbucket" or "gitea".
+	Forge *string
+	// BaseURL overrides the API endpoint used by Forge, required for
+	// self-hosted GitLab/Gitea/Bitbucket Server instances.
+	BaseURL *string
+	// Token authenticates requests against the selected Forge's API.
+	Token *string
+
+	// Verify enables live credential checks against each matched secret's
+	// issuing service before it is reported as a Finding.
+	Verify *bool
+	// VerifyWorkers bounds how many verifications run concurrently.
+	VerifyWorkers *int
+	// VerifyRatePerSecond throttles how many verifications start per
+	// second; 0 disables throttling.
+	VerifyRatePerSecond *int
+
+	// IncludeRepos, if non-empty, restricts scanning to repos whose
+	// "owner/name" matches one of these globs.
+	IncludeRepos *[]string
+	// IgnoreRepos excludes repos whose "owner/name" matches one of these globs.
+	IgnoreRepos *[]string
+	// IncludeForks scans forked repositories; skipped by default.
+	IncludeForks *bool
+	// IncludeArchived scans archived repositories; skipped by default.
+	IncludeArchived *bool
+
+	// ExcludePaths holds gitignore-style globs applied on top of each
+	// repo's own .gitignore and .yarignore, skipping matching files before
+	// any entropy/regex work is done against their diffs.
+	ExcludePaths *[]string
+
+	// OutputFormat selects how Findings are rendered: "pretty" (default,
+	// human-readable), "json" or "sarif".
+	OutputFormat *string
+}
@@ -0,0 +1,47 @@
+package robber
+
+import (
+	"context"
+	"fmt"
+)
+
+// Repo describes a single repository as returned by a Forge, carrying just
+// enough metadata for RepoCache to dedup and filter it before it is queued
+// for scanning.
+type Repo struct {
+	// Name is the repo's full "owner/name"-style identifier, matched against
+	// --include-repos/--ignore-repos globs.
+	Name       string
+	CloneURL   string
+	IsFork     bool
+	IsArchived bool
+}
+
+// Forge abstracts enumeration of repositories and members across the
+// different code hosting platforms yar can scan. AnalyzeUser/AnalyzeOrg only
+// talk to a Forge, so AnalyzeRepo itself stays forge-agnostic.
+type Forge interface {
+	// ListUserRepos returns every repository owned by user.
+	ListUserRepos(ctx context.Context, user string) ([]*Repo, error)
+	// ListOrgRepos returns every repository owned by org.
+	ListOrgRepos(ctx context.Context, org string) ([]*Repo, error)
+	// ListOrgMembers returns the usernames of every member of org.
+	ListOrgMembers(ctx context.Context, org string) ([]string, error)
+}
+
+// NewForge builds the Forge selected by name, pointed at baseURL when the
+// platform is self-hosted. An empty baseURL uses the platform's public API.
+func NewForge(name, baseURL, token string) (Forge, error) {
+	switch name {
+	case "", "github":
+		return NewGitHubForge(baseURL, token), nil
+	case "gitlab":
+		return NewGitLabForge(baseURL, token), nil
+	case "bitbucket":
+		return NewBitbucketForge(baseURL, token), nil
+	case "gitea":
+		return NewGiteaForge(baseURL, token), nil
+	default:
+		return nil, fmt.Errorf("unknown forge %q", name)
+	}
+}
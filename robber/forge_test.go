@@ -0,0 +1,116 @@
+package robber
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGithubRepoToRepo(t *testing.T) {
+	var r githubRepo
+	body := `{"full_name":"acme/repo","clone_url":"https://github.com/acme/repo.git","fork":true,"archived":false}`
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	repo := r.toRepo()
+	if repo.Name != "acme/repo" || repo.CloneURL != "https://github.com/acme/repo.git" || !repo.IsFork || repo.IsArchived {
+		t.Fatalf("toRepo() = %+v, want {acme/repo https://github.com/acme/repo.git true false}", repo)
+	}
+}
+
+func TestGiteaRepoToRepo(t *testing.T) {
+	var r giteaRepo
+	body := `{"full_name":"acme/repo","clone_url":"https://git.example.com/acme/repo.git","fork":false,"archived":true}`
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	repo := r.toRepo()
+	if repo.Name != "acme/repo" || repo.CloneURL != "https://git.example.com/acme/repo.git" || repo.IsFork || !repo.IsArchived {
+		t.Fatalf("toRepo() = %+v, want {acme/repo https://git.example.com/acme/repo.git false true}", repo)
+	}
+}
+
+func TestGitlabProjectToRepoForked(t *testing.T) {
+	var p gitlabProject
+	body := `{
+		"path_with_namespace": "acme/repo",
+		"http_url_to_repo": "https://gitlab.com/acme/repo.git",
+		"forked_from_project": {"id": 42},
+		"archived": true
+	}`
+	if err := json.Unmarshal([]byte(body), &p); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	repo := p.toRepo()
+	if !repo.IsFork {
+		t.Fatal("a project with a non-null forked_from_project should be IsFork")
+	}
+	if !repo.IsArchived {
+		t.Fatal("expected IsArchived true")
+	}
+	if repo.Name != "acme/repo" || repo.CloneURL != "https://gitlab.com/acme/repo.git" {
+		t.Fatalf("toRepo() = %+v", repo)
+	}
+}
+
+func TestGitlabProjectToRepoNotForked(t *testing.T) {
+	var p gitlabProject
+	body := `{"path_with_namespace":"acme/repo","http_url_to_repo":"https://gitlab.com/acme/repo.git","forked_from_project":null,"archived":false}`
+	if err := json.Unmarshal([]byte(body), &p); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if repo := p.toRepo(); repo.IsFork {
+		t.Fatal("a project with a null forked_from_project should not be IsFork")
+	}
+}
+
+func TestBitbucketRepoPageReposSelectsHTTPSCloneURL(t *testing.T) {
+	var page bitbucketRepoPage
+	body := `{
+		"values": [
+			{
+				"full_name": "acme/repo",
+				"parent": null,
+				"links": {
+					"clone": [
+						{"name": "ssh", "href": "git@bitbucket.org:acme/repo.git"},
+						{"name": "https", "href": "https://bitbucket.org/acme/repo.git"}
+					]
+				}
+			}
+		]
+	}`
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	repos := page.repos()
+	if len(repos) != 1 {
+		t.Fatalf("got %d repos, want 1", len(repos))
+	}
+	repo := repos[0]
+	if repo.CloneURL != "https://bitbucket.org/acme/repo.git" {
+		t.Fatalf("CloneURL = %q, want the https clone link regardless of array order", repo.CloneURL)
+	}
+	if repo.IsFork {
+		t.Fatal("a repo with a null parent should not be IsFork")
+	}
+}
+
+func TestBitbucketRepoPageReposDetectsFork(t *testing.T) {
+	var page bitbucketRepoPage
+	body := `{
+		"values": [
+			{
+				"full_name": "acme/repo-fork",
+				"parent": {"full_name": "upstream/repo"},
+				"links": {"clone": [{"name": "https", "href": "https://bitbucket.org/acme/repo-fork.git"}]}
+			}
+		]
+	}`
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	repos := page.repos()
+	if len(repos) != 1 || !repos[0].IsFork {
+		t.Fatalf("repos = %+v, want a single IsFork repo", repos)
+	}
+}
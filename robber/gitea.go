@@ -0,0 +1,93 @@
+package robber
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GiteaForge implements Forge against a self-hosted Gitea instance.
+// Unlike the other forges there is no public default: a --base-url is
+// required to point at the instance to scan.
+type GiteaForge struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewGiteaForge builds a GiteaForge against baseURL (e.g. https://git.example.com/api/v1).
+func NewGiteaForge(baseURL, token string) *GiteaForge {
+	return &GiteaForge{baseURL: baseURL, token: token, client: http.DefaultClient}
+}
+
+func (g *GiteaForge) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", g.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	if g.token != "" {
+		req.Header.Set("Authorization", "token "+g.token)
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gitea: %s: unexpected status %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type giteaRepo struct {
+	FullName string `json:"full_name"`
+	CloneURL string `json:"clone_url"`
+	Fork     bool   `json:"fork"`
+	Archived bool   `json:"archived"`
+}
+
+func (r giteaRepo) toRepo() *Repo {
+	return &Repo{Name: r.FullName, CloneURL: r.CloneURL, IsFork: r.Fork, IsArchived: r.Archived}
+}
+
+// ListUserRepos implements Forge.
+func (g *GiteaForge) ListUserRepos(ctx context.Context, user string) ([]*Repo, error) {
+	var repos []giteaRepo
+	if err := g.get(ctx, fmt.Sprintf("/users/%s/repos", user), &repos); err != nil {
+		return nil, err
+	}
+	out := make([]*Repo, len(repos))
+	for i, r := range repos {
+		out[i] = r.toRepo()
+	}
+	return out, nil
+}
+
+// ListOrgRepos implements Forge.
+func (g *GiteaForge) ListOrgRepos(ctx context.Context, org string) ([]*Repo, error) {
+	var repos []giteaRepo
+	if err := g.get(ctx, fmt.Sprintf("/orgs/%s/repos", org), &repos); err != nil {
+		return nil, err
+	}
+	out := make([]*Repo, len(repos))
+	for i, r := range repos {
+		out[i] = r.toRepo()
+	}
+	return out, nil
+}
+
+// ListOrgMembers implements Forge.
+func (g *GiteaForge) ListOrgMembers(ctx context.Context, org string) ([]string, error) {
+	var members []struct {
+		Login string `json:"login"`
+	}
+	if err := g.get(ctx, fmt.Sprintf("/orgs/%s/members", org), &members); err != nil {
+		return nil, err
+	}
+	logins := make([]string, len(members))
+	for i, m := range members {
+		logins[i] = m.Login
+	}
+	return logins, nil
+}
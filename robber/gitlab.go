@@ -0,0 +1,104 @@
+package robber
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const defaultGitLabBaseURL = "https://gitlab.com/api/v4"
+
+// GitLabForge implements Forge against gitlab.com or a self-hosted instance.
+type GitLabForge struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewGitLabForge builds a GitLabForge. An empty baseURL targets gitlab.com.
+func NewGitLabForge(baseURL, token string) *GitLabForge {
+	if baseURL == "" {
+		baseURL = defaultGitLabBaseURL
+	}
+	return &GitLabForge{baseURL: baseURL, token: token, client: http.DefaultClient}
+}
+
+func (g *GitLabForge) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", g.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	if g.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", g.token)
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gitlab: %s: unexpected status %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type gitlabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+	HTTPURLToRepo     string `json:"http_url_to_repo"`
+	ForkedFromProject *struct {
+		ID int `json:"id"`
+	} `json:"forked_from_project"`
+	Archived bool `json:"archived"`
+}
+
+func (p gitlabProject) toRepo() *Repo {
+	return &Repo{
+		Name:       p.PathWithNamespace,
+		CloneURL:   p.HTTPURLToRepo,
+		IsFork:     p.ForkedFromProject != nil,
+		IsArchived: p.Archived,
+	}
+}
+
+// ListUserRepos implements Forge.
+func (g *GitLabForge) ListUserRepos(ctx context.Context, user string) ([]*Repo, error) {
+	var projects []gitlabProject
+	if err := g.get(ctx, "/users/"+url.PathEscape(user)+"/projects", &projects); err != nil {
+		return nil, err
+	}
+	out := make([]*Repo, len(projects))
+	for i, p := range projects {
+		out[i] = p.toRepo()
+	}
+	return out, nil
+}
+
+// ListOrgRepos implements Forge, treating org as a GitLab group.
+func (g *GitLabForge) ListOrgRepos(ctx context.Context, org string) ([]*Repo, error) {
+	var projects []gitlabProject
+	if err := g.get(ctx, "/groups/"+url.PathEscape(org)+"/projects", &projects); err != nil {
+		return nil, err
+	}
+	out := make([]*Repo, len(projects))
+	for i, p := range projects {
+		out[i] = p.toRepo()
+	}
+	return out, nil
+}
+
+// ListOrgMembers implements Forge.
+func (g *GitLabForge) ListOrgMembers(ctx context.Context, org string) ([]string, error) {
+	var members []struct {
+		Username string `json:"username"`
+	}
+	if err := g.get(ctx, "/groups/"+url.PathEscape(org)+"/members", &members); err != nil {
+		return nil, err
+	}
+	logins := make([]string, len(members))
+	for i, m := range members {
+		logins[i] = m.Username
+	}
+	return logins, nil
+}
@@ -0,0 +1,68 @@
+package robber
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// jsonFinding is the wire shape a Finding is rendered as by JSONEmitter.
+type jsonFinding struct {
+	Reason          string `json:"reason"`
+	Reponame        string `json:"repo"`
+	Filepath        string `json:"file"`
+	Commit          string `json:"commit"`
+	Secret          string `json:"secret"`
+	Verification    string `json:"verification"`
+	LastAuthor      string `json:"last_author,omitempty"`
+	LastAuthorEmail string `json:"last_author_email,omitempty"`
+	LastCommit      string `json:"last_commit,omitempty"`
+	LineInHEAD      int    `json:"line_in_head,omitempty"`
+	StillPresent    bool   `json:"still_present"`
+}
+
+// JSONEmitter buffers every Finding and writes them as a single JSON array
+// once the scan completes.
+type JSONEmitter struct {
+	w io.Writer
+
+	mu       sync.Mutex
+	findings []jsonFinding
+}
+
+// NewJSONEmitter builds a JSONEmitter writing to w.
+func NewJSONEmitter(w io.Writer) *JSONEmitter {
+	return &JSONEmitter{w: w}
+}
+
+// EmitFinding implements Emitter.
+func (j *JSONEmitter) EmitFinding(finding *Finding, m *Middleware, context string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.findings = append(j.findings, jsonFinding{
+		Reason:          finding.Reason,
+		Reponame:        finding.Reponame,
+		Filepath:        finding.Filepath,
+		Commit:          finding.Commit,
+		Secret:          secretText(finding, context),
+		Verification:    finding.Verification.String(),
+		LastAuthor:      finding.LastAuthor,
+		LastAuthorEmail: finding.LastAuthorEmail,
+		LastCommit:      finding.LastCommit,
+		LineInHEAD:      finding.LineInHEAD,
+		StillPresent:    finding.StillPresent,
+	})
+}
+
+// Flush implements Emitter, writing every buffered Finding as a JSON array.
+func (j *JSONEmitter) Flush() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	findings := j.findings
+	if findings == nil {
+		findings = []jsonFinding{}
+	}
+	enc := json.NewEncoder(j.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(findings)
+}
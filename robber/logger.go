@@ -0,0 +1,45 @@
+package robber
+
+import (
+	"fmt"
+	"os"
+)
+
+// Logger prints scan progress and delegates Finding rendering to Emitter.
+type Logger struct {
+	// Emitter renders each reported Finding. A nil Emitter falls back to
+	// yar's original human-readable printer on stdout.
+	Emitter Emitter
+}
+
+// LogWarn reports a recoverable problem that does not abort the scan.
+func (l *Logger) LogWarn(format string, args ...interface{}) {
+	fmt.Printf("[!] "+format, args...)
+}
+
+// LogFail reports an unrecoverable problem with a single repository.
+func (l *Logger) LogFail(format string, args ...interface{}) {
+	fmt.Printf("[x] "+format, args...)
+}
+
+// LogFinding hands finding to the configured Emitter together with the diff
+// context its Secret offsets are relative to.
+func (l *Logger) LogFinding(finding *Finding, m *Middleware, diff string) {
+	l.emitter().EmitFinding(finding, m, diff)
+}
+
+// Flush flushes the configured Emitter, writing any output buffered for
+// document-shaped formats like json/sarif. Call once the scan completes.
+func (l *Logger) Flush() error {
+	if l.Emitter == nil {
+		return nil
+	}
+	return l.Emitter.Flush()
+}
+
+func (l *Logger) emitter() Emitter {
+	if l.Emitter != nil {
+		return l.Emitter
+	}
+	return NewPrettyEmitter(os.Stdout)
+}
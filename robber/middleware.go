@@ -0,0 +1,80 @@
+package robber
+
+import (
+	"context"
+	"sync"
+)
+
+// Middleware bundles all shared state threaded through the analysis
+// functions: configuration flags, rule set, logger, forge client and the
+// seen-secret cache used for deduplication.
+type Middleware struct {
+	Flags     *Flags
+	Rules     []*Rule
+	Logger    *Logger
+	Forge     Forge
+	RepoCount *int32
+
+	Verifiers  []Verifier
+	VerifyPool *VerifyPool
+
+	RepoCache  *RepoCache
+	BlameCache *BlameCache
+
+	secretsMu sync.Mutex
+	secrets   map[string]map[string]bool
+}
+
+// VerifierFor returns the first registered Verifier willing to check
+// secrets matched by rule, or nil if none claims it.
+func (m *Middleware) VerifierFor(rule *Rule) Verifier {
+	for _, v := range m.Verifiers {
+		if v.CanVerify(rule) {
+			return v
+		}
+	}
+	return nil
+}
+
+// DefaultVerifiers returns the built-in Verifiers yar ships for common
+// credential types. githubBaseURL is forwarded to GitHubTokenVerifier so a
+// GitHub Enterprise scan (--forge github --base-url ...) verifies tokens
+// against that same instance instead of public github.com.
+func DefaultVerifiers(githubBaseURL string) []Verifier {
+	return []Verifier{
+		NewAWSKeyVerifier(),
+		NewGitHubTokenVerifier(githubBaseURL),
+		NewSlackTokenVerifier(),
+		NewStripeKeyVerifier(),
+		NewGCPServiceAccountVerifier(),
+	}
+}
+
+// SecretExists reports whether secret has already been recorded for reponame.
+func (m *Middleware) SecretExists(reponame, secret string) bool {
+	m.secretsMu.Lock()
+	defer m.secretsMu.Unlock()
+	return m.secrets[reponame][secret]
+}
+
+// AddSecret records secret as seen for reponame.
+func (m *Middleware) AddSecret(reponame, secret string) {
+	m.secretsMu.Lock()
+	defer m.secretsMu.Unlock()
+	if m.secrets[reponame] == nil {
+		m.secrets[reponame] = make(map[string]bool)
+	}
+	m.secrets[reponame][secret] = true
+}
+
+// ScanContext builds the root context a scan runs under, applying
+// --timeout as an overall deadline when set. The returned cancel must be
+// called once the scan finishes; AnalyzeRepo also calls it itself once
+// RepoCount reaches zero, so a caller normally just `defer cancel()`s it
+// and otherwise lets the workers drive completion.
+func (m *Middleware) ScanContext() (context.Context, context.CancelFunc) {
+	if m.Flags.Timeout != nil && *m.Flags.Timeout > 0 {
+		return context.WithTimeout(context.Background(), *m.Flags.Timeout)
+	}
+	return context.WithCancel(context.Background())
+}
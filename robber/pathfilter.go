@@ -0,0 +1,65 @@
+package robber
+
+import (
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/format/gitignore"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// yarignoreFile is a repo-local, yar-specific complement to .gitignore: it
+// follows the same pattern syntax but is for excluding paths (vendored
+// deps, fixtures, minified bundles) that should stay tracked in git yet
+// never be tokenized for secrets.
+const yarignoreFile = ".yarignore"
+
+// PathFilter decides whether a file path should be skipped before any
+// entropy/regex work is done against it, based on the repo's own
+// .gitignore, a repo-local .yarignore, and the global --exclude-paths
+// globs. It is built once per repo and reused across every commit/change
+// in that repo's scan.
+type PathFilter struct {
+	matcher gitignore.Matcher
+}
+
+// NewPathFilter loads .gitignore and .yarignore from head, appends
+// excludePaths as additional patterns, and builds the PathFilter that
+// results. A head that has neither file yields a filter driven solely by
+// excludePaths.
+func NewPathFilter(head *object.Commit, excludePaths []string) *PathFilter {
+	var patterns []gitignore.Pattern
+	patterns = append(patterns, readIgnorePatterns(head, ".gitignore")...)
+	patterns = append(patterns, readIgnorePatterns(head, yarignoreFile)...)
+	for _, p := range excludePaths {
+		patterns = append(patterns, gitignore.ParsePattern(p, nil))
+	}
+	return &PathFilter{matcher: gitignore.NewMatcher(patterns)}
+}
+
+// Excluded reports whether path should be skipped, per f's patterns.
+func (f *PathFilter) Excluded(path string) bool {
+	return f.matcher.Match(strings.Split(path, "/"), false)
+}
+
+// readIgnorePatterns reads name out of commit's tree and parses it as a
+// gitignore-style pattern file, returning nil if the file doesn't exist.
+func readIgnorePatterns(commit *object.Commit, name string) []gitignore.Pattern {
+	file, err := commit.File(name)
+	if err != nil {
+		return nil
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return nil
+	}
+
+	var patterns []gitignore.Pattern
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, nil))
+	}
+	return patterns
+}
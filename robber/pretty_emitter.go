@@ -0,0 +1,30 @@
+package robber
+
+import (
+	"fmt"
+	"io"
+)
+
+// PrettyEmitter is yar's original human-readable Finding printer.
+type PrettyEmitter struct {
+	w io.Writer
+}
+
+// NewPrettyEmitter builds a PrettyEmitter writing to w.
+func NewPrettyEmitter(w io.Writer) *PrettyEmitter {
+	return &PrettyEmitter{w: w}
+}
+
+// EmitFinding implements Emitter.
+func (p *PrettyEmitter) EmitFinding(finding *Finding, m *Middleware, context string) {
+	fmt.Fprintf(p.w, "[+] %s (%s:%s) [%s]\n%s\n", finding.Reason, finding.Reponame, finding.Filepath, finding.Verification, context)
+	if finding.LastAuthor != "" {
+		fmt.Fprintf(p.w, "    last touched by %s <%s> in %s, line %d in HEAD, still present: %t\n",
+			finding.LastAuthor, finding.LastAuthorEmail, finding.LastCommit, finding.LineInHEAD, finding.StillPresent)
+	}
+}
+
+// Flush implements Emitter; PrettyEmitter writes as it goes.
+func (p *PrettyEmitter) Flush() error {
+	return nil
+}
@@ -0,0 +1,166 @@
+package robber
+
+import (
+	"context"
+	"strings"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/storage/memory"
+)
+
+// OpenRepo clones reponame into an in-memory storage so its history can be walked.
+// Canceling ctx aborts the clone.
+func OpenRepo(ctx context.Context, m *Middleware, reponame string) (*git.Repository, error) {
+	return git.CloneContext(ctx, memory.NewStorage(), nil, &git.CloneOptions{
+		URL: reponame,
+	})
+}
+
+// GetCommits returns every commit reachable from HEAD, oldest last, stopping
+// early if ctx is canceled.
+func GetCommits(ctx context.Context, m *Middleware, repo *git.Repository, reponame string) ([]*object.Commit, error) {
+	ref, err := repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	cIter, err := repo.Log(&git.LogOptions{From: ref.Hash()})
+	if err != nil {
+		return nil, err
+	}
+	var commits []*object.Commit
+	err = cIter.ForEach(func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		commits = append(commits, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+// GetCommitChanges returns the tree changes introduced by commit relative to
+// its first parent (or relative to an empty tree for root commits).
+func GetCommitChanges(commit *object.Commit) (object.Changes, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	if commit.NumParents() == 0 {
+		return (&object.Tree{}).Diff(tree)
+	}
+
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return nil, err
+	}
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return nil, err
+	}
+	return parentTree.Diff(tree)
+}
+
+// ChangePath returns the path change applies to: its new path, or its old
+// path if the change deleted the file. Resolving this doesn't require
+// computing the change's patch, so callers can use it to skip ignored
+// paths before ever tokenizing their diff.
+func ChangePath(change *object.Change) (string, error) {
+	_, to, err := change.Files()
+	if err != nil {
+		return "", err
+	}
+	if to == nil {
+		return change.From.Name, nil
+	}
+	return change.To.Name, nil
+}
+
+// GetDiffs returns the text of each chunk of change's patch (added, deleted
+// and unchanged chunks alike, without +/- prefixes) alongside the path it
+// applies to.
+func GetDiffs(m *Middleware, change *object.Change, reponame string) ([]string, string, error) {
+	filepath, err := ChangePath(change)
+	if err != nil {
+		return nil, "", err
+	}
+
+	patch, err := change.Patch()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var diffs []string
+	for _, filePatch := range patch.FilePatches() {
+		for _, chunk := range filePatch.Chunks() {
+			diffs = append(diffs, chunk.Content())
+		}
+	}
+	return diffs, filepath, nil
+}
+
+// BlameFinding enriches finding with attribution for secret as it stands in
+// repo's HEAD: who (LastAuthor/LastAuthorEmail) and which commit
+// (LastCommit) git blame credits for the matching line, what line that is
+// today (LineInHEAD), and whether the secret is StillPresent at all.
+// It is a no-op (finding left unenriched) if filepath no longer exists at
+// HEAD or the secret can't be found in it.
+func BlameFinding(m *Middleware, repo *git.Repository, reponame, filepath, secret string, finding *Finding) {
+	head, err := repo.Head()
+	if err != nil {
+		return
+	}
+	headCommit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return
+	}
+
+	lineInHEAD, lineText, ok := findLineInHEAD(headCommit, filepath, secret)
+	finding.StillPresent = ok
+	if !ok {
+		return
+	}
+	finding.LineInHEAD = lineInHEAD
+	finding.LineInHEADText = lineText
+
+	blame, err := m.BlameCache.Blame(reponame, filepath, headCommit)
+	if err != nil || lineInHEAD > len(blame.Lines) {
+		return
+	}
+	line := blame.Lines[lineInHEAD-1]
+	finding.LastCommit = line.Hash.String()
+	finding.LastAuthorEmail = line.Author
+	if c, err := repo.CommitObject(line.Hash); err == nil {
+		finding.LastAuthor = c.Author.Name
+	}
+}
+
+// findLineInHEAD returns the 1-based line number and text of the first line
+// in filepath at commit that contains secret.
+func findLineInHEAD(commit *object.Commit, filepath, secret string) (int, string, bool) {
+	file, err := commit.File(filepath)
+	if err != nil {
+		return 0, "", false
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return 0, "", false
+	}
+	return lineContaining(content, secret)
+}
+
+// lineContaining returns the 1-based line number and text of the first line
+// in content that contains secret. It takes plain content rather than a git
+// object so it can be unit tested without a real commit.
+func lineContaining(content, secret string) (int, string, bool) {
+	for i, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, secret) {
+			return i + 1, line, true
+		}
+	}
+	return 0, "", false
+}
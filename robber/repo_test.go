@@ -0,0 +1,23 @@
+package robber
+
+import "testing"
+
+func TestLineContainingFindsFirstMatchingLine(t *testing.T) {
+	content := "foo\nbar SECRET baz\nqux SECRET\n"
+	line, text, ok := lineContaining(content, "SECRET")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if line != 2 {
+		t.Fatalf("line = %d, want 2", line)
+	}
+	if text != "bar SECRET baz" {
+		t.Fatalf("text = %q, want %q", text, "bar SECRET baz")
+	}
+}
+
+func TestLineContainingNoMatch(t *testing.T) {
+	if _, _, ok := lineContaining("foo\nbar\n", "SECRET"); ok {
+		t.Fatal("expected no match")
+	}
+}
@@ -0,0 +1,118 @@
+package robber
+
+import (
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+// RepoCache deduplicates repositories across AnalyzeUser/AnalyzeOrg calls
+// (an org repo owned by a member, forks, case variants, trailing `.git`)
+// and applies the --include-repos/--ignore-repos glob lists before a
+// repository is ever pushed onto the scan channel or counted towards
+// RepoCount. It is safe for concurrent use across the goroutines spawned
+// per org member.
+type RepoCache struct {
+	mu   sync.Mutex
+	seen map[string]bool
+
+	includeRepos    []string
+	ignoreRepos     []string
+	includeForks    bool
+	includeArchived bool
+}
+
+// NewRepoCache builds a RepoCache filtering against the given glob lists and
+// fork/archived toggles. A nil or empty includeRepos matches everything.
+func NewRepoCache(includeRepos, ignoreRepos []string, includeForks, includeArchived bool) *RepoCache {
+	return &RepoCache{
+		seen:            make(map[string]bool),
+		includeRepos:    includeRepos,
+		ignoreRepos:     ignoreRepos,
+		includeForks:    includeForks,
+		includeArchived: includeArchived,
+	}
+}
+
+// normalizeCloneURL lowercases the host, trims a trailing `.git` and any trailing
+// slash, so that host case variants and `.git`-suffixed/unsuffixed clone
+// URLs for the same repository collapse to one key. The owner/repo path is
+// left as-is: it's case-sensitive on some forges, so lowercasing it could
+// collapse two distinct repositories into one.
+func normalizeCloneURL(cloneURL string) string {
+	trimmed := strings.TrimSpace(cloneURL)
+	trimmed = strings.TrimSuffix(trimmed, "/")
+	trimmed = strings.TrimSuffix(trimmed, ".git")
+
+	if scp, ok := lowercaseSCPLikeHost(trimmed); ok {
+		return scp
+	}
+
+	parsed, err := url.Parse(trimmed)
+	if err != nil || parsed.Host == "" {
+		return trimmed
+	}
+	parsed.Host = strings.ToLower(parsed.Host)
+	return parsed.String()
+}
+
+// lowercaseSCPLikeHost recognizes the scp-like "user@host:owner/repo" clone
+// URL form (e.g. "git@github.com:acme/repo"), which url.Parse rejects
+// because it treats everything before the first "/" as a single path
+// segment containing a colon. It returns cloneURL with only the host
+// portion lowercased, or ok=false if cloneURL isn't in this form.
+func lowercaseSCPLikeHost(cloneURL string) (string, bool) {
+	if strings.Contains(cloneURL, "://") {
+		return "", false
+	}
+	at := strings.Index(cloneURL, "@")
+	if at < 0 {
+		return "", false
+	}
+	rest := cloneURL[at+1:]
+	colon := strings.Index(rest, ":")
+	if colon < 0 {
+		return "", false
+	}
+	return cloneURL[:at+1] + strings.ToLower(rest[:colon]) + rest[colon:], true
+}
+
+// matchesAny reports whether name matches any of the given glob patterns.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ShouldScan reports whether repo passes the include/ignore filters and has
+// not already been admitted by a previous call, recording it as seen if so.
+// Repositories rejected by the filters are never recorded as seen, so they
+// can't accidentally suppress a differently-filtered duplicate.
+func (c *RepoCache) ShouldScan(repo *Repo) bool {
+	if len(c.includeRepos) > 0 && !matchesAny(c.includeRepos, repo.Name) {
+		return false
+	}
+	if matchesAny(c.ignoreRepos, repo.Name) {
+		return false
+	}
+	if !c.includeForks && repo.IsFork {
+		return false
+	}
+	if !c.includeArchived && repo.IsArchived {
+		return false
+	}
+
+	key := normalizeCloneURL(repo.CloneURL)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.seen[key] {
+		return false
+	}
+	c.seen[key] = true
+	return true
+}
@@ -0,0 +1,60 @@
+package robber
+
+import "testing"
+
+func TestNormalizeCloneURLLowercasesHostOnly(t *testing.T) {
+	cases := map[string]string{
+		"https://GitHub.com/Acme/Repo.git": "https://github.com/Acme/Repo",
+		"https://github.com/Acme/Repo/":    "https://github.com/Acme/Repo",
+		"https://github.com/Acme/Repo":     "https://github.com/Acme/Repo",
+		"git@GitHub.com:Acme/Repo.git":     "git@github.com:Acme/Repo",
+		"git@github.com:Acme/Repo":         "git@github.com:Acme/Repo",
+	}
+	for in, want := range cases {
+		if got := normalizeCloneURL(in); got != want {
+			t.Errorf("normalizeCloneURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRepoCacheShouldScanDedupesHostCaseNotPathCase(t *testing.T) {
+	cache := NewRepoCache(nil, nil, true, true)
+
+	if !cache.ShouldScan(&Repo{Name: "acme/Repo", CloneURL: "https://GitHub.com/Acme/Repo.git"}) {
+		t.Fatal("first sighting of a repo should be scanned")
+	}
+	if cache.ShouldScan(&Repo{Name: "acme/Repo", CloneURL: "https://github.com/Acme/Repo"}) {
+		t.Fatal("a host-case/`.git`-suffix variant of an already-seen repo should be deduped")
+	}
+	if !cache.ShouldScan(&Repo{Name: "acme/repo", CloneURL: "https://github.com/acme/repo"}) {
+		t.Fatal("a clone URL differing only in path case is a distinct repo on case-sensitive forges and should be scanned")
+	}
+}
+
+func TestRepoCacheShouldScanDedupesSCPLikeHostCaseNotPathCase(t *testing.T) {
+	cache := NewRepoCache(nil, nil, true, true)
+
+	if !cache.ShouldScan(&Repo{Name: "acme/Repo", CloneURL: "git@GitHub.com:Acme/Repo.git"}) {
+		t.Fatal("first sighting of a repo should be scanned")
+	}
+	if cache.ShouldScan(&Repo{Name: "acme/Repo", CloneURL: "git@github.com:Acme/Repo"}) {
+		t.Fatal("an scp-like host-case/`.git`-suffix variant of an already-seen repo should be deduped")
+	}
+	if !cache.ShouldScan(&Repo{Name: "acme/repo", CloneURL: "git@github.com:acme/repo"}) {
+		t.Fatal("an scp-like clone URL differing only in path case is a distinct repo and should be scanned")
+	}
+}
+
+func TestRepoCacheShouldScanAppliesIncludeIgnoreGlobs(t *testing.T) {
+	cache := NewRepoCache([]string{"acme/*"}, []string{"acme/secret"}, true, true)
+
+	if !cache.ShouldScan(&Repo{Name: "acme/repo", CloneURL: "https://github.com/acme/repo"}) {
+		t.Fatal("repo matching includeRepos should be scanned")
+	}
+	if cache.ShouldScan(&Repo{Name: "other/repo", CloneURL: "https://github.com/other/repo"}) {
+		t.Fatal("repo not matching includeRepos should be skipped")
+	}
+	if cache.ShouldScan(&Repo{Name: "acme/secret", CloneURL: "https://github.com/acme/secret"}) {
+		t.Fatal("repo matching ignoreRepos should be skipped")
+	}
+}
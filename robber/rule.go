@@ -0,0 +1,64 @@
+package robber
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/src-d/go-git.v4/plumbing/format/gitignore"
+)
+
+// Rule pairs a detection regex with the human readable reason shown on a match.
+type Rule struct {
+	Reason string
+	Regex  *regexp.Regexp
+
+	// PathInclude, if non-empty, restricts the rule to files whose path
+	// matches one of these globs, e.g. limiting a "PEM block" rule to
+	// *.pem/*.key. A nil or empty PathInclude matches every path.
+	PathInclude []string
+	// PathExclude skips the rule for files whose path matches any of
+	// these globs.
+	PathExclude []string
+
+	// pathMatchOnce compiles includeMatcher/excludeMatcher from
+	// PathInclude/PathExclude the first time AppliesToPath runs, so a rule
+	// re-used across every diff only pays the gitignore.Pattern parse cost
+	// once rather than once per diff.
+	pathMatchOnce  sync.Once
+	includeMatcher gitignore.Matcher
+	excludeMatcher gitignore.Matcher
+}
+
+// AppliesToPath reports whether the rule should run against path, per its
+// PathInclude/PathExclude globs.
+func (r *Rule) AppliesToPath(path string) bool {
+	r.pathMatchOnce.Do(func() {
+		if len(r.PathInclude) > 0 {
+			r.includeMatcher = newPathGlobMatcher(r.PathInclude)
+		}
+		if len(r.PathExclude) > 0 {
+			r.excludeMatcher = newPathGlobMatcher(r.PathExclude)
+		}
+	})
+
+	segments := strings.Split(path, "/")
+	if r.includeMatcher != nil && !r.includeMatcher.Match(segments, false) {
+		return false
+	}
+	if r.excludeMatcher != nil && r.excludeMatcher.Match(segments, false) {
+		return false
+	}
+	return true
+}
+
+// newPathGlobMatcher builds a gitignore.Matcher for the given globs, so a
+// bare "*.pem" matches that filename in any directory the same way a real
+// .gitignore entry would.
+func newPathGlobMatcher(patterns []string) gitignore.Matcher {
+	parsed := make([]gitignore.Pattern, len(patterns))
+	for i, p := range patterns {
+		parsed[i] = gitignore.ParsePattern(p, nil)
+	}
+	return gitignore.NewMatcher(parsed)
+}
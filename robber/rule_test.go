@@ -0,0 +1,42 @@
+package robber
+
+import "testing"
+
+func TestRuleAppliesToPathWithNoGlobsMatchesEverything(t *testing.T) {
+	r := &Rule{Reason: "test"}
+	if !r.AppliesToPath("anything.go") {
+		t.Fatal("a rule with no PathInclude/PathExclude should apply to every path")
+	}
+}
+
+func TestRuleAppliesToPathHonorsInclude(t *testing.T) {
+	r := &Rule{Reason: "test", PathInclude: []string{"*.pem", "*.key"}}
+	if !r.AppliesToPath("certs/server.pem") {
+		t.Fatal("expected server.pem to match *.pem")
+	}
+	if r.AppliesToPath("main.go") {
+		t.Fatal("main.go should not match *.pem/*.key")
+	}
+}
+
+func TestRuleAppliesToPathHonorsExclude(t *testing.T) {
+	r := &Rule{Reason: "test", PathExclude: []string{"vendor/*"}}
+	if r.AppliesToPath("vendor/lib.go") {
+		t.Fatal("vendor/lib.go should be excluded by vendor/*")
+	}
+	if !r.AppliesToPath("main.go") {
+		t.Fatal("main.go should not be excluded")
+	}
+}
+
+func TestRuleAppliesToPathCachesAcrossCalls(t *testing.T) {
+	r := &Rule{Reason: "test", PathInclude: []string{"*.pem"}}
+	for i := 0; i < 3; i++ {
+		if !r.AppliesToPath("a.pem") {
+			t.Fatalf("call %d: expected a.pem to match", i)
+		}
+	}
+	if r.includeMatcher == nil {
+		t.Fatal("expected includeMatcher to be compiled and cached")
+	}
+}
@@ -0,0 +1,179 @@
+package robber
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	sarifSchema  = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// sarifLog is the root of a SARIF 2.1.0 log document.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string                     `json:"name"`
+	InformationURI string                     `json:"informationUri"`
+	Rules          []sarifReportingDescriptor `json:"rules"`
+}
+
+// sarifReportingDescriptor is SARIF's per-rule metadata, one per distinct
+// Finding.Reason yar has matched (regex Rules and the entropy checks alike).
+type sarifReportingDescriptor struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	// Region is omitted when the finding could not be located at HEAD
+	// (BlameFinding never ran, or the secret isn't StillPresent there),
+	// since there is then no HEAD line/column to report.
+	Region *sarifRegion `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// SARIFEmitter buffers every Finding and, on Flush, writes a single SARIF
+// 2.1.0 log: one reportingDescriptor per distinct Finding.Reason, and one
+// result per Finding with a partialFingerprints hash of repo+file+secret so
+// GitHub/GitLab code scanning can dedup findings across runs.
+type SARIFEmitter struct {
+	w io.Writer
+
+	mu      sync.Mutex
+	ruleIDs map[string]string
+	rules   []sarifReportingDescriptor
+	results []sarifResult
+}
+
+// NewSARIFEmitter builds a SARIFEmitter writing to w.
+func NewSARIFEmitter(w io.Writer) *SARIFEmitter {
+	return &SARIFEmitter{w: w, ruleIDs: make(map[string]string)}
+}
+
+// EmitFinding implements Emitter.
+func (s *SARIFEmitter) EmitFinding(finding *Finding, m *Middleware, context string) {
+	secret := secretText(finding, context)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.results = append(s.results, sarifResult{
+		RuleID:  s.ruleIDFor(finding.Reason),
+		Message: sarifMessage{Text: finding.Reason},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: finding.Filepath},
+				Region:           sarifRegionFor(finding.LineInHEAD, finding.LineInHEADText, secret),
+			},
+		}},
+		PartialFingerprints: map[string]string{
+			"yar/v1": fingerprint(finding.Reponame, finding.Filepath, secret),
+		},
+	})
+}
+
+// sarifRegionFor builds the Region for a finding blamed to line line of
+// lineText, or nil if line is 0 (the finding couldn't be located at HEAD).
+// Columns are located within lineText itself rather than the diff-relative
+// offsets in Finding.Secret, since those are only valid within the
+// diff snippet the finding was matched in, not the real HEAD line.
+func sarifRegionFor(line int, lineText, secret string) *sarifRegion {
+	if line <= 0 {
+		return nil
+	}
+	region := &sarifRegion{StartLine: line}
+	if idx := strings.Index(lineText, secret); idx >= 0 {
+		region.StartColumn = idx + 1
+		region.EndColumn = idx + len(secret) + 1
+	}
+	return region
+}
+
+// ruleIDFor returns the stable ruleId for reason, registering a new
+// reportingDescriptor for it on first use. Caller must hold s.mu.
+func (s *SARIFEmitter) ruleIDFor(reason string) string {
+	if id, ok := s.ruleIDs[reason]; ok {
+		return id
+	}
+	id := slugify(reason)
+	s.ruleIDs[reason] = id
+	s.rules = append(s.rules, sarifReportingDescriptor{
+		ID:               id,
+		ShortDescription: sarifMessage{Text: reason},
+	})
+	return id
+}
+
+// Flush implements Emitter, writing the accumulated results as one SARIF log.
+func (s *SARIFEmitter) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := s.results
+	if results == nil {
+		results = []sarifResult{}
+	}
+	rules := s.rules
+	if rules == nil {
+		rules = []sarifReportingDescriptor{}
+	}
+
+	doc := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "yar",
+				InformationURI: "https://github.com/gemsi/yar",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(s.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,35 @@
+package robber
+
+import "testing"
+
+func TestSarifRegionForUnlocatedFindingIsNil(t *testing.T) {
+	if region := sarifRegionFor(0, "", "secret"); region != nil {
+		t.Fatalf("sarifRegionFor(0, ...) = %+v, want nil", region)
+	}
+}
+
+func TestSarifRegionForLocatesColumnWithinLineText(t *testing.T) {
+	region := sarifRegionFor(7, `key := "SECRETVALUE"`, "SECRETVALUE")
+	if region == nil {
+		t.Fatal("expected a non-nil region")
+	}
+	if region.StartLine != 7 {
+		t.Fatalf("StartLine = %d, want 7", region.StartLine)
+	}
+	if region.StartColumn != 9 {
+		t.Fatalf("StartColumn = %d, want 9", region.StartColumn)
+	}
+	if region.EndColumn != 9+len("SECRETVALUE") {
+		t.Fatalf("EndColumn = %d, want %d", region.EndColumn, 9+len("SECRETVALUE"))
+	}
+}
+
+func TestSarifRegionForSecretNotInLineTextOmitsColumns(t *testing.T) {
+	region := sarifRegionFor(3, "some unrelated line", "SECRET")
+	if region == nil {
+		t.Fatal("expected a non-nil region")
+	}
+	if region.StartColumn != 0 || region.EndColumn != 0 {
+		t.Fatalf("expected zero columns when the secret isn't found in lineText, got %+v", region)
+	}
+}
@@ -0,0 +1,72 @@
+package robber
+
+import (
+	"math"
+	"strings"
+)
+
+// Max returns the larger of a and b.
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Min returns the smaller of a and b.
+func Min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// FindValidStrings extracts the maximal substrings of word made up entirely
+// of characters from charset. Code taken from https://github.com/dxa4481/truffleHog.
+func FindValidStrings(word, charset string) []string {
+	var found []string
+	var current []byte
+	for i := 0; i < len(word); i++ {
+		if strings.ContainsRune(charset, rune(word[i])) {
+			current = append(current, word[i])
+			continue
+		}
+		if len(current) > 0 {
+			found = append(found, string(current))
+			current = nil
+		}
+	}
+	if len(current) > 0 {
+		found = append(found, string(current))
+	}
+	return found
+}
+
+// shannonEntropy computes the Shannon entropy of data in bits per character.
+func shannonEntropy(data string) float64 {
+	if data == "" {
+		return 0
+	}
+	freq := make(map[rune]float64)
+	for _, r := range data {
+		freq[r]++
+	}
+	var entropy float64
+	for _, count := range freq {
+		p := count / float64(len(data))
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// PrintEntropyFinding reports every candidate string whose Shannon entropy
+// exceeds threshold as a Finding.
+func PrintEntropyFinding(candidates []string, m *Middleware, d *DiffObject, threshold float64) {
+	for _, s := range candidates {
+		if shannonEntropy(s) > threshold {
+			finding := NewFinding("High entropy string", []int{0, len(s)}, d)
+			blameFinding(m, d, s, finding)
+			m.Logger.LogFinding(finding, m, s)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package robber
+
+import "context"
+
+// VerificationResult is the outcome of checking whether a matched secret is
+// still a live, usable credential.
+type VerificationResult int
+
+const (
+	// VerificationUnknown means the secret's validity could not be
+	// determined: no Verifier claimed it, the check was canceled or failed,
+	// or the Verifier got an answer it could not classify.
+	VerificationUnknown VerificationResult = iota
+	// VerificationVerified means the credential was confirmed live against
+	// its issuing service.
+	VerificationVerified
+	// VerificationUnverified means a Verifier checked the credential and it
+	// was rejected (revoked, rotated, or never valid).
+	VerificationUnverified
+)
+
+// String renders a VerificationResult the way it is tagged on a Finding.
+func (v VerificationResult) String() string {
+	switch v {
+	case VerificationVerified:
+		return "verified"
+	case VerificationUnverified:
+		return "unverified"
+	default:
+		return "unknown"
+	}
+}
+
+// Verifier performs a live credential check against the service a secret
+// was presumably issued by. CanVerify lets Middleware pick the right
+// Verifier for a given Rule without hardcoding rule names.
+type Verifier interface {
+	// CanVerify reports whether this Verifier knows how to check secrets
+	// matched by rule.
+	CanVerify(rule *Rule) bool
+	// Verify checks secret against its issuing service.
+	Verify(ctx context.Context, secret string) (VerificationResult, error)
+}
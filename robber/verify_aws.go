@@ -0,0 +1,47 @@
+package robber
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// AWSKeyVerifier checks AWS access keys by calling sts:GetCallerIdentity,
+// which succeeds for any key/secret pair with valid, unexpired credentials
+// regardless of what else they're allowed to do.
+type AWSKeyVerifier struct{}
+
+// NewAWSKeyVerifier builds an AWSKeyVerifier.
+func NewAWSKeyVerifier() *AWSKeyVerifier {
+	return &AWSKeyVerifier{}
+}
+
+// CanVerify implements Verifier.
+func (v *AWSKeyVerifier) CanVerify(rule *Rule) bool {
+	return strings.Contains(strings.ToLower(rule.Reason), "aws")
+}
+
+// Verify implements Verifier. secret is expected to be "accessKeyID:secretAccessKey".
+// Nothing currently matches a rule that supplies both halves together, so a
+// secret that doesn't parse is reported VerificationUnknown rather than the
+// misleadingly confident VerificationUnverified.
+func (v *AWSKeyVerifier) Verify(ctx context.Context, secret string) (VerificationResult, error) {
+	parts := strings.SplitN(secret, ":", 2)
+	if len(parts) != 2 {
+		return VerificationUnknown, nil
+	}
+
+	cfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider(parts[0], parts[1], ""),
+	}
+	client := sts.NewFromConfig(cfg)
+
+	if _, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}); err != nil {
+		return VerificationUnverified, nil
+	}
+	return VerificationVerified, nil
+}
@@ -0,0 +1,41 @@
+package robber
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"golang.org/x/oauth2/google"
+)
+
+// GCPServiceAccountVerifier checks GCP service-account JSON keys by
+// exchanging them for an OAuth2 token; a revoked or malformed key key fails
+// the exchange.
+type GCPServiceAccountVerifier struct{}
+
+// NewGCPServiceAccountVerifier builds a GCPServiceAccountVerifier.
+func NewGCPServiceAccountVerifier() *GCPServiceAccountVerifier {
+	return &GCPServiceAccountVerifier{}
+}
+
+// CanVerify implements Verifier.
+func (v *GCPServiceAccountVerifier) CanVerify(rule *Rule) bool {
+	return strings.Contains(strings.ToLower(rule.Reason), "gcp") || strings.Contains(strings.ToLower(rule.Reason), "service account")
+}
+
+// Verify implements Verifier.
+func (v *GCPServiceAccountVerifier) Verify(ctx context.Context, secret string) (VerificationResult, error) {
+	if !json.Valid([]byte(secret)) {
+		return VerificationUnverified, nil
+	}
+
+	config, err := google.JWTConfigFromJSON([]byte(secret), "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		return VerificationUnverified, nil
+	}
+
+	if _, err := config.TokenSource(ctx).Token(); err != nil {
+		return VerificationUnverified, nil
+	}
+	return VerificationVerified, nil
+}
@@ -0,0 +1,53 @@
+package robber
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// GitHubTokenVerifier checks personal access tokens and app tokens by
+// calling GET /user, which succeeds for any authenticated GitHub token.
+type GitHubTokenVerifier struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewGitHubTokenVerifier builds a GitHubTokenVerifier checking tokens
+// against baseURL. An empty baseURL targets github.com, matching
+// NewGitHubForge so --verify checks a GitHub Enterprise token against the
+// same instance --base-url pointed the scan at.
+func NewGitHubTokenVerifier(baseURL string) *GitHubTokenVerifier {
+	if baseURL == "" {
+		baseURL = defaultGitHubBaseURL
+	}
+	return &GitHubTokenVerifier{baseURL: baseURL, client: http.DefaultClient}
+}
+
+// CanVerify implements Verifier.
+func (v *GitHubTokenVerifier) CanVerify(rule *Rule) bool {
+	return strings.Contains(strings.ToLower(rule.Reason), "github")
+}
+
+// Verify implements Verifier.
+func (v *GitHubTokenVerifier) Verify(ctx context.Context, secret string) (VerificationResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", v.baseURL+"/user", nil)
+	if err != nil {
+		return VerificationUnknown, err
+	}
+	req.Header.Set("Authorization", "token "+secret)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return VerificationUnknown, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return VerificationVerified, nil
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return VerificationUnverified, nil
+	}
+	return VerificationUnknown, nil
+}
@@ -0,0 +1,59 @@
+package robber
+
+import (
+	"context"
+	"time"
+)
+
+// VerifyPool bounds how many Verifier.Verify calls run concurrently and, if
+// configured with a nonzero rate, throttles how many start per second. This
+// keeps credential verification from stalling or overwhelming the diff loop
+// feeding it.
+type VerifyPool struct {
+	sem     chan struct{}
+	limiter <-chan time.Time
+}
+
+// NewVerifyPool builds a VerifyPool allowing workers concurrent verifications.
+// A ratePerSecond of 0 disables throttling.
+func NewVerifyPool(workers, ratePerSecond int) *VerifyPool {
+	if workers <= 0 {
+		workers = 1
+	}
+	pool := &VerifyPool{sem: make(chan struct{}, workers)}
+	if ratePerSecond > 0 {
+		pool.limiter = time.Tick(time.Second / time.Duration(ratePerSecond))
+	}
+	return pool
+}
+
+// Verify finds the Verifier registered for rule, if any, and runs it through
+// the pool's worker/rate limits. It returns VerificationUnknown if no
+// Verifier claims rule, ctx is canceled, or the check itself errors.
+func (p *VerifyPool) Verify(ctx context.Context, m *Middleware, rule *Rule, secret string) VerificationResult {
+	verifier := m.VerifierFor(rule)
+	if verifier == nil {
+		return VerificationUnknown
+	}
+
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return VerificationUnknown
+	}
+	defer func() { <-p.sem }()
+
+	if p.limiter != nil {
+		select {
+		case <-p.limiter:
+		case <-ctx.Done():
+			return VerificationUnknown
+		}
+	}
+
+	result, err := verifier.Verify(ctx, secret)
+	if err != nil {
+		return VerificationUnknown
+	}
+	return result
+}
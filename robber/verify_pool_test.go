@@ -0,0 +1,118 @@
+package robber
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubVerifier is a Verifier whose Verify blocks until release is closed,
+// so tests can observe how many run concurrently.
+type stubVerifier struct {
+	canVerify bool
+	release   chan struct{}
+
+	running int32
+	maxSeen int32
+}
+
+func (s *stubVerifier) CanVerify(rule *Rule) bool { return s.canVerify }
+
+func (s *stubVerifier) Verify(ctx context.Context, secret string) (VerificationResult, error) {
+	n := atomic.AddInt32(&s.running, 1)
+	for {
+		old := atomic.LoadInt32(&s.maxSeen)
+		if n <= old || atomic.CompareAndSwapInt32(&s.maxSeen, old, n) {
+			break
+		}
+	}
+	<-s.release
+	atomic.AddInt32(&s.running, -1)
+	return VerificationVerified, nil
+}
+
+func TestVerifyPoolBoundsConcurrency(t *testing.T) {
+	const workers = 2
+	verifier := &stubVerifier{canVerify: true, release: make(chan struct{})}
+	m := &Middleware{Verifiers: []Verifier{verifier}}
+	pool := NewVerifyPool(workers, 0)
+	rule := &Rule{Reason: "test"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers+3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pool.Verify(context.Background(), m, rule, "secret")
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(verifier.release)
+	wg.Wait()
+
+	if max := atomic.LoadInt32(&verifier.maxSeen); max > workers {
+		t.Fatalf("VerifyPool let %d verifications run concurrently, want at most %d", max, workers)
+	}
+}
+
+func TestVerifyPoolHonorsCancellation(t *testing.T) {
+	verifier := &stubVerifier{canVerify: true, release: make(chan struct{})}
+	defer close(verifier.release)
+	m := &Middleware{Verifiers: []Verifier{verifier}}
+	pool := NewVerifyPool(1, 0)
+	rule := &Rule{Reason: "test"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The pool's single slot is already in use, so a canceled ctx must
+	// return immediately instead of blocking on it.
+	done := make(chan struct{})
+	go func() {
+		pool.Verify(context.Background(), m, rule, "holds the slot")
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	result := pool.Verify(ctx, m, rule, "secret")
+	if result != VerificationUnknown {
+		t.Fatalf("Verify with a canceled ctx returned %s, want %s", result, VerificationUnknown)
+	}
+
+	verifier.release <- struct{}{}
+	<-done
+}
+
+func TestVerifyPoolNoVerifierIsUnknown(t *testing.T) {
+	m := &Middleware{Verifiers: nil}
+	pool := NewVerifyPool(1, 0)
+	rule := &Rule{Reason: "aws access key"}
+
+	if result := pool.Verify(context.Background(), m, rule, "secret"); result != VerificationUnknown {
+		t.Fatalf("Verify with no registered Verifier returned %s, want %s", result, VerificationUnknown)
+	}
+}
+
+func TestMiddlewareVerifierForDispatchesByCanVerify(t *testing.T) {
+	matching := &stubVerifier{canVerify: true, release: make(chan struct{})}
+	close(matching.release)
+	other := &stubVerifier{canVerify: false, release: make(chan struct{})}
+	m := &Middleware{Verifiers: []Verifier{other, matching}}
+
+	if got := m.VerifierFor(&Rule{Reason: "test"}); got != matching {
+		t.Fatalf("VerifierFor returned %v, want the Verifier whose CanVerify is true", got)
+	}
+}
+
+func TestAWSKeyVerifierCanVerify(t *testing.T) {
+	v := NewAWSKeyVerifier()
+	if !v.CanVerify(&Rule{Reason: "AWS Access Key"}) {
+		t.Fatal("AWSKeyVerifier should claim a rule whose reason mentions AWS")
+	}
+	if v.CanVerify(&Rule{Reason: "Slack Token"}) {
+		t.Fatal("AWSKeyVerifier should not claim an unrelated rule")
+	}
+}
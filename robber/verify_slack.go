@@ -0,0 +1,52 @@
+package robber
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SlackTokenVerifier checks Slack bot/user tokens via auth.test, which
+// returns ok:true for any still-valid token regardless of scopes.
+type SlackTokenVerifier struct {
+	client *http.Client
+}
+
+// NewSlackTokenVerifier builds a SlackTokenVerifier.
+func NewSlackTokenVerifier() *SlackTokenVerifier {
+	return &SlackTokenVerifier{client: http.DefaultClient}
+}
+
+// CanVerify implements Verifier.
+func (v *SlackTokenVerifier) CanVerify(rule *Rule) bool {
+	return strings.Contains(strings.ToLower(rule.Reason), "slack")
+}
+
+// Verify implements Verifier.
+func (v *SlackTokenVerifier) Verify(ctx context.Context, secret string) (VerificationResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://slack.com/api/auth.test", strings.NewReader(url.Values{}.Encode()))
+	if err != nil {
+		return VerificationUnknown, err
+	}
+	req.Header.Set("Authorization", "Bearer "+secret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return VerificationUnknown, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		OK bool `json:"ok"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return VerificationUnknown, err
+	}
+	if body.OK {
+		return VerificationVerified, nil
+	}
+	return VerificationUnverified, nil
+}
@@ -0,0 +1,46 @@
+package robber
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// StripeKeyVerifier checks Stripe API keys by calling a cheap authenticated
+// endpoint; Stripe returns 401 for a revoked or malformed key.
+type StripeKeyVerifier struct {
+	client *http.Client
+}
+
+// NewStripeKeyVerifier builds a StripeKeyVerifier.
+func NewStripeKeyVerifier() *StripeKeyVerifier {
+	return &StripeKeyVerifier{client: http.DefaultClient}
+}
+
+// CanVerify implements Verifier.
+func (v *StripeKeyVerifier) CanVerify(rule *Rule) bool {
+	return strings.Contains(strings.ToLower(rule.Reason), "stripe")
+}
+
+// Verify implements Verifier.
+func (v *StripeKeyVerifier) Verify(ctx context.Context, secret string) (VerificationResult, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.stripe.com/v1/charges?limit=1", nil)
+	if err != nil {
+		return VerificationUnknown, err
+	}
+	req.SetBasicAuth(secret, "")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return VerificationUnknown, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return VerificationVerified, nil
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		return VerificationUnverified, nil
+	}
+	return VerificationUnknown, nil
+}
@@ -0,0 +1,47 @@
+package robber
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAWSKeyVerifierUnparseableSecretIsUnknown(t *testing.T) {
+	v := NewAWSKeyVerifier()
+	result, err := v.Verify(context.Background(), "not-a-key-pair")
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result != VerificationUnknown {
+		t.Fatalf("Verify(%q) = %v, want %v (can't misreport an unparsed secret as a rejected credential)", "not-a-key-pair", result, VerificationUnknown)
+	}
+}
+
+func TestGitHubTokenVerifierUsesConfiguredBaseURL(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	v := NewGitHubTokenVerifier(srv.URL)
+	result, err := v.Verify(context.Background(), "token")
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result != VerificationVerified {
+		t.Fatalf("Verify() = %v, want %v", result, VerificationVerified)
+	}
+	if gotPath != "/user" {
+		t.Fatalf("request hit path %q, want /user on the configured base URL %q", gotPath, srv.URL)
+	}
+}
+
+func TestGitHubTokenVerifierDefaultsToPublicGitHub(t *testing.T) {
+	v := NewGitHubTokenVerifier("")
+	if v.baseURL != defaultGitHubBaseURL {
+		t.Fatalf("baseURL = %q, want %q", v.baseURL, defaultGitHubBaseURL)
+	}
+}